@@ -0,0 +1,152 @@
+package dynv6
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/libdns/dynv6/internal"
+	"github.com/libdns/libdns"
+)
+
+func toLibdnsRecord(r *internal.Record) libdns.Record {
+	rec := libdns.Record{
+		ID:   strconv.FormatInt(r.ID, 10),
+		Type: r.Type,
+		Name: r.Name,
+		TTL:  60 * time.Second, //dynv6's REST API does not allow for custom TTL values
+	}
+	switch r.Type {
+	case "CAA":
+		rec.Value = fmt.Sprintf("%d %s %s", r.Flags, r.Tag, strconv.Quote(r.Data))
+	case "SRV":
+		rec.Value = fmt.Sprintf("%d %d %d %s", r.Priority, r.Weight, r.Port, r.Data)
+	case "SSHFP":
+		rec.Value = fmt.Sprintf("%d %s %s", r.Flags, r.Tag, r.Data)
+	default:
+		rec.Value = r.Data
+	}
+	return rec
+}
+
+func fromLibdnsRecord(zone string, rec *libdns.Record) (internal.Record, error) {
+	var (
+		id  int64
+		err error
+	)
+	if rec.ID != "" {
+		id, err = strconv.ParseInt(rec.ID, 10, 64)
+		if err != nil {
+			return internal.Record{}, err
+		}
+	}
+
+	r := internal.Record{
+		ID:   id,
+		Type: rec.Type,
+		Name: strings.TrimSuffix(rec.Name, "."+strings.TrimSuffix(zone, ".")),
+	}
+
+	switch rec.Type {
+	case "CAA":
+		flags, tag, value, err := parseCAAValue(rec.Value)
+		if err != nil {
+			return internal.Record{}, err
+		}
+		r.Flags, r.Tag, r.Data = flags, tag, value
+	case "SRV":
+		priority, weight, port, target, err := parseSRVValue(rec.Value)
+		if err != nil {
+			return internal.Record{}, err
+		}
+		r.Priority, r.Weight, r.Port, r.Data = priority, weight, port, target
+	case "SSHFP":
+		algorithm, fpType, fingerprint, err := parseSSHFPValue(rec.Value)
+		if err != nil {
+			return internal.Record{}, err
+		}
+		r.Flags, r.Tag, r.Data = algorithm, fpType, fingerprint
+	default:
+		r.Data = rec.Value
+	}
+
+	return r, nil
+}
+
+// parseCAAValue parses the RFC 6844 presentation form "<flags> <tag> \"<value>\"".
+func parseCAAValue(value string) (flags int64, tag string, data string, err error) {
+	fields := strings.SplitN(strings.TrimSpace(value), " ", 3)
+	if len(fields) != 3 {
+		return 0, "", "", fmt.Errorf(`invalid CAA value %q: expected "<flags> <tag> <value>"`, value)
+	}
+	flags, err = strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return 0, "", "", fmt.Errorf("invalid CAA flags in %q: %w", value, err)
+	}
+	tag = fields[1]
+	data, err = strconv.Unquote(fields[2])
+	if err != nil {
+		return 0, "", "", fmt.Errorf("invalid CAA value in %q: %w", value, err)
+	}
+	return flags, tag, data, nil
+}
+
+// parseSRVValue parses the libdns SRV convention "<priority> <weight> <port> <target>".
+func parseSRVValue(value string) (priority, weight, port int64, target string, err error) {
+	fields := strings.Fields(strings.TrimSpace(value))
+	if len(fields) != 4 {
+		return 0, 0, 0, "", fmt.Errorf(`invalid SRV value %q: expected "<priority> <weight> <port> <target>"`, value)
+	}
+	priority, err = strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return 0, 0, 0, "", fmt.Errorf("invalid SRV priority in %q: %w", value, err)
+	}
+	weight, err = strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return 0, 0, 0, "", fmt.Errorf("invalid SRV weight in %q: %w", value, err)
+	}
+	port, err = strconv.ParseInt(fields[2], 10, 64)
+	if err != nil {
+		return 0, 0, 0, "", fmt.Errorf("invalid SRV port in %q: %w", value, err)
+	}
+	return priority, weight, port, fields[3], nil
+}
+
+// parseSSHFPValue parses "<algorithm> <type> <fingerprint>", storing algorithm
+// in the record's Flags field and type in its Tag field.
+func parseSSHFPValue(value string) (algorithm int64, fpType string, fingerprint string, err error) {
+	fields := strings.Fields(strings.TrimSpace(value))
+	if len(fields) != 3 {
+		return 0, "", "", fmt.Errorf(`invalid SSHFP value %q: expected "<algorithm> <type> <fingerprint>"`, value)
+	}
+	algorithm, err = strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return 0, "", "", fmt.Errorf("invalid SSHFP algorithm in %q: %w", value, err)
+	}
+	return algorithm, fields[1], fields[2], nil
+}
+
+func findRecord(recs []internal.Record, r *libdns.Record) *internal.Record {
+	for _, v := range recs {
+		if v.Type == r.Type && v.Name == r.Name {
+			return &v
+		}
+	}
+	return nil
+}
+
+func findRecordWithValue(zone string, recs []internal.Record, r *libdns.Record) (*internal.Record, error) {
+	candidate, err := fromLibdnsRecord(zone, r)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range recs {
+		if v.Type == candidate.Type && v.Name == candidate.Name && v.Data == candidate.Data &&
+			v.Flags == candidate.Flags && v.Tag == candidate.Tag &&
+			v.Priority == candidate.Priority && v.Weight == candidate.Weight && v.Port == candidate.Port {
+			return &v, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,69 @@
+package dynv6
+
+import (
+	"testing"
+
+	"github.com/libdns/dynv6/internal"
+	"github.com/libdns/libdns"
+)
+
+func TestRecordRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		rec  internal.Record
+	}{
+		{
+			name: "A",
+			rec:  internal.Record{ID: 1, Type: "A", Name: "www", Data: "192.0.2.1"},
+		},
+		{
+			name: "CAA",
+			rec:  internal.Record{ID: 2, Type: "CAA", Name: "@", Flags: 0, Tag: "issue", Data: "letsencrypt.org"},
+		},
+		{
+			name: "SRV",
+			rec:  internal.Record{ID: 3, Type: "SRV", Name: "_sip._tcp", Priority: 10, Weight: 20, Port: 5060, Data: "sipserver.example.com."},
+		},
+		{
+			name: "SSHFP",
+			rec:  internal.Record{ID: 4, Type: "SSHFP", Name: "@", Flags: 4, Tag: "2", Data: "123456789abcdef67890123456789abcdef67890123456789abcdef1234567"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			libdnsRec := toLibdnsRecord(&tt.rec)
+
+			got, err := fromLibdnsRecord("example.com.", &libdnsRec)
+			if err != nil {
+				t.Fatalf("fromLibdnsRecord() error = %v", err)
+			}
+			if got.Type != tt.rec.Type || got.Name != tt.rec.Name || got.Data != tt.rec.Data ||
+				got.Flags != tt.rec.Flags || got.Tag != tt.rec.Tag ||
+				got.Priority != tt.rec.Priority || got.Weight != tt.rec.Weight || got.Port != tt.rec.Port {
+				t.Errorf("round trip mismatch: got %+v, want %+v", got, tt.rec)
+			}
+		})
+	}
+}
+
+func TestFromLibdnsRecordMalformed(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{name: "CAA", value: "0 issue"},
+		{name: "CAA", value: "notanumber issue \"letsencrypt.org\""},
+		{name: "SRV", value: "10 20 5060"},
+		{name: "SRV", value: "notanumber 20 5060 sipserver.example.com."},
+		{name: "SSHFP", value: "4 2"},
+		{name: "SSHFP", value: "notanumber 2 abcdef"},
+	}
+
+	for _, tt := range tests {
+		rec := &libdns.Record{Type: tt.name, Name: "@", Value: tt.value}
+		if _, err := fromLibdnsRecord("example.com.", rec); err == nil {
+			t.Errorf("fromLibdnsRecord(%s, %q) expected error, got nil", tt.name, tt.value)
+		}
+	}
+}
@@ -0,0 +1,279 @@
+// Package internal provides an HTTP client for the dynv6 API, handling
+// authentication, retries, and rate limiting so the provider package can
+// stay focused on the libdns interfaces.
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultBaseURL = "https://dynv6.com/api/v2/"
+
+const maxRetries = 4
+
+// StatusError is returned when the dynv6 API responds with a non-2xx status
+// code that was not retried (or that exhausted its retries).
+type StatusError struct {
+	StatusCode int
+	Status     string
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status code: %s, response: %s", e.Status, e.Body)
+}
+
+// Client is an HTTP client for the dynv6 API.
+type Client struct {
+	// HTTPClient is used to perform requests. Override it to inject a
+	// proxy, custom TLS config, or a test double. Defaults to a client
+	// with a 60 second timeout.
+	HTTPClient *http.Client
+
+	// BaseURL is the root of the dynv6 API. Defaults to https://dynv6.com/api/v2/.
+	BaseURL *url.URL
+
+	token       string
+	rateLimiter *rateLimiter
+}
+
+// NewClient creates a new Client authenticating with token.
+func NewClient(token string) *Client {
+	baseURL, _ := url.Parse(defaultBaseURL)
+	return &Client{
+		HTTPClient: &http.Client{Timeout: 60 * time.Second},
+		BaseURL:    baseURL,
+		token:      token,
+	}
+}
+
+// SetRateLimit caps outgoing requests to requestsPerSecond. A value <= 0 disables the limit.
+func (c *Client) SetRateLimit(requestsPerSecond float64) {
+	if requestsPerSecond <= 0 {
+		c.rateLimiter = nil
+		return
+	}
+	c.rateLimiter = newRateLimiter(requestsPerSecond)
+}
+
+// Zone is a dynv6 zone.
+type Zone struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	IPv4address string    `json:"ipv4address"`
+	IPv6prefix  string    `json:"ipv6prefix"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// Record is a dynv6 DNS record.
+type Record struct {
+	ExpandedData string `json:"expandedData,omitempty"`
+	ID           int64  `json:"id,omitempty"`
+	ZoneID       int64  `json:"zoneID,omitempty"`
+	Type         string `json:"type"`
+	Name         string `json:"name"`
+	Data         string `json:"data"`
+	Priority     int64  `json:"priority,omitempty"`
+	Flags        int64  `json:"flags,omitempty"`
+	Tag          string `json:"tag,omitempty"`
+	Weight       int64  `json:"weight,omitempty"`
+	Port         int64  `json:"port,omitempty"`
+}
+
+// ListZones lists all zones accessible with the client's token.
+func (c *Client) ListZones(ctx context.Context) ([]Zone, error) {
+	var zones []Zone
+	if err := c.do(ctx, http.MethodGet, "zones", nil, &zones); err != nil {
+		return nil, err
+	}
+	return zones, nil
+}
+
+// GetZoneByName looks up a zone by its name.
+func (c *Client) GetZoneByName(ctx context.Context, name string) (*Zone, error) {
+	name = strings.TrimSuffix(name, ".")
+	var z Zone
+	if err := c.do(ctx, http.MethodGet, "zones/by-name/"+name, nil, &z); err != nil {
+		return nil, err
+	}
+	return &z, nil
+}
+
+// GetZoneByID looks up a zone by its numeric ID.
+func (c *Client) GetZoneByID(ctx context.Context, id int64) (*Zone, error) {
+	var z Zone
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf("zones/%d", id), nil, &z); err != nil {
+		return nil, err
+	}
+	return &z, nil
+}
+
+// ListRecords lists all records in the zone.
+func (c *Client) ListRecords(ctx context.Context, zoneID int64) ([]Record, error) {
+	var records []Record
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf("zones/%d/records", zoneID), nil, &records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// CreateRecord creates a record in the zone.
+func (c *Client) CreateRecord(ctx context.Context, zoneID int64, rec Record) (*Record, error) {
+	var created Record
+	if err := c.do(ctx, http.MethodPost, fmt.Sprintf("zones/%d/records", zoneID), rec, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// UpdateRecord updates rec, which must have its ID set.
+func (c *Client) UpdateRecord(ctx context.Context, zoneID int64, rec Record) (*Record, error) {
+	var updated Record
+	path := fmt.Sprintf("zones/%d/records/%d", zoneID, rec.ID)
+	if err := c.do(ctx, http.MethodPatch, path, rec, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// DeleteRecord deletes the record with the given ID from the zone.
+func (c *Client) DeleteRecord(ctx context.Context, zoneID, recordID int64) error {
+	path := fmt.Sprintf("zones/%d/records/%d", zoneID, recordID)
+	return c.do(ctx, http.MethodDelete, path, nil, nil)
+}
+
+// GetZoneFile downloads the zone's records in RFC 1035 zone file syntax.
+func (c *Client) GetZoneFile(ctx context.Context, zoneID int64) (string, error) {
+	var buf bytes.Buffer
+	if err := c.doRaw(ctx, http.MethodGet, fmt.Sprintf("zones/%d/zonefile", zoneID), "", nil, &buf); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// PutZoneFile replaces the zone's records with the contents of zoneFile, an RFC 1035 zone file.
+func (c *Client) PutZoneFile(ctx context.Context, zoneID int64, zoneFile string) error {
+	path := fmt.Sprintf("zones/%d/zonefile", zoneID)
+	return c.doRaw(ctx, http.MethodPut, path, "text/dns", strings.NewReader(zoneFile), nil)
+}
+
+// do performs a JSON request/response round trip against path, retrying on 429/5xx.
+func (c *Client) do(ctx context.Context, method, path string, reqBody, out interface{}) error {
+	var body io.Reader
+	var contentType string
+	if reqBody != nil {
+		b, err := json.Marshal(reqBody)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+		contentType = "application/json"
+	}
+
+	var buf bytes.Buffer
+	if err := c.doRaw(ctx, method, path, contentType, body, &buf); err != nil {
+		return err
+	}
+	if out == nil || buf.Len() == 0 {
+		return nil
+	}
+	return json.Unmarshal(buf.Bytes(), out)
+}
+
+// doRaw performs a request against path, writing the response body to out (if non-nil)
+// and retrying on 429/5xx with exponential backoff, honoring Retry-After when present.
+// contentType is set on the request only when body is non-nil; pass "" when it doesn't apply.
+func (c *Client) doRaw(ctx context.Context, method, path, contentType string, body io.Reader, out io.Writer) error {
+	u, err := c.BaseURL.Parse(path)
+	if err != nil {
+		return err
+	}
+
+	var bodyBytes []byte
+	if body != nil {
+		bodyBytes, err = ioutil.ReadAll(body)
+		if err != nil {
+			return err
+		}
+	}
+
+	var lastErr error
+	var wait time.Duration
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(wait):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		if c.rateLimiter != nil {
+			if err := c.rateLimiter.wait(ctx); err != nil {
+				return err
+			}
+		}
+
+		var reqBody io.Reader
+		if bodyBytes != nil {
+			reqBody = bytes.NewReader(bodyBytes)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, u.String(), reqBody)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", "Bearer "+c.token)
+		if bodyBytes != nil && contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+
+		resp, err := c.HTTPClient.Do(req)
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+			respBytes, _ := ioutil.ReadAll(resp.Body)
+			lastErr = &StatusError{StatusCode: resp.StatusCode, Status: resp.Status, Body: string(respBytes)}
+			wait = retryDelay(resp, attempt)
+			resp.Body.Close()
+			continue
+		}
+
+		respBytes, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return &StatusError{StatusCode: resp.StatusCode, Status: resp.Status, Body: string(respBytes)}
+		}
+		if out != nil {
+			_, err = out.Write(respBytes)
+		}
+		return err
+	}
+	return fmt.Errorf("giving up after %d attempts: %w", maxRetries+1, lastErr)
+}
+
+// retryDelay honors a Retry-After header if present, falling back to exponential backoff.
+func retryDelay(resp *http.Response, attempt int) time.Duration {
+	if ra := resp.Header.Get("Retry-After"); ra != "" {
+		if secs, err := strconv.Atoi(ra); err == nil {
+			return time.Duration(secs) * time.Second
+		}
+	}
+	return time.Duration(math.Pow(2, float64(attempt))) * time.Second
+}
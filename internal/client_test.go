@@ -0,0 +1,109 @@
+package internal
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	c := NewClient("test")
+	baseURL, err := url.Parse(server.URL + "/")
+	if err != nil {
+		t.Fatalf("parsing test server URL: %v", err)
+	}
+	c.BaseURL = baseURL
+	return c
+}
+
+func TestDoRetriesOn429ThenSucceeds(t *testing.T) {
+	var calls int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"id":1,"name":"example.com"}`))
+	})
+
+	z, err := c.GetZoneByName(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("GetZoneByName() error = %v", err)
+	}
+	if z.ID != 1 {
+		t.Errorf("GetZoneByName() = %+v, want ID 1", z)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("expected 2 requests (1 retry after 429), got %d", got)
+	}
+}
+
+func TestDoGivesUpAfterMaxRetries(t *testing.T) {
+	var calls int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	_, err := c.GetZoneByName(context.Background(), "example.com")
+	if err == nil {
+		t.Fatal("GetZoneByName() expected error, got nil")
+	}
+	if got := atomic.LoadInt32(&calls); got != maxRetries+1 {
+		t.Errorf("expected %d attempts, got %d", maxRetries+1, got)
+	}
+}
+
+func TestRetryDelayHonorsRetryAfterHeader(t *testing.T) {
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"7"}}}
+	if got := retryDelay(resp, 0); got != 7*time.Second {
+		t.Errorf("retryDelay() = %v, want 7s", got)
+	}
+}
+
+func TestSetRateLimitSpacesRequests(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":1,"name":"example.com"}`))
+	})
+	c.SetRateLimit(20) // one request every 50ms
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := c.GetZoneByName(context.Background(), "example.com"); err != nil {
+			t.Fatalf("GetZoneByName() error = %v", err)
+		}
+	}
+	elapsed := time.Since(start)
+
+	const interval = time.Second / 20
+	if elapsed < 2*interval {
+		t.Errorf("3 requests at 20 req/s completed in %v, want at least %v", elapsed, 2*interval)
+	}
+}
+
+func TestRetryDelayFallsBackToExponentialBackoff(t *testing.T) {
+	resp := &http.Response{Header: http.Header{}}
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{0, 1 * time.Second},
+		{1, 2 * time.Second},
+		{2, 4 * time.Second},
+	}
+	for _, tt := range tests {
+		if got := retryDelay(resp, tt.attempt); got != tt.want {
+			t.Errorf("retryDelay(attempt=%d) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+}
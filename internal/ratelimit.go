@@ -0,0 +1,38 @@
+package internal
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// rateLimiter spaces out requests so outgoing traffic never exceeds a
+// configured requests/sec rate. It is a minimal token bucket of size one.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	last     time.Time
+}
+
+func newRateLimiter(requestsPerSecond float64) *rateLimiter {
+	return &rateLimiter{interval: time.Duration(float64(time.Second) / requestsPerSecond)}
+}
+
+// wait blocks until it is safe to send another request, or ctx is done.
+func (r *rateLimiter) wait(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	next := r.last.Add(r.interval)
+	if d := next.Sub(now); d > 0 {
+		select {
+		case <-time.After(d):
+			now = time.Now()
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	r.last = now
+	return nil
+}
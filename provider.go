@@ -0,0 +1,214 @@
+// Package dynv6 implements a DNS record management client compatible
+// with the libdns interfaces for dynv6.
+package dynv6
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/libdns/dynv6/internal"
+	"github.com/libdns/libdns"
+)
+
+// Provider facilitates DNS record manipulation with dynv6.
+type Provider struct {
+	// Token is the dynv6 HTTP token, scoped to the zones it should manage.
+	Token string `json:"token,omitempty"`
+
+	// UseZoneFile switches GetRecords/SetRecords/AppendRecords/DeleteRecords
+	// to go through dynv6's zone-file endpoint instead of the regular
+	// per-record REST endpoints. The REST API hard-codes a 60 second TTL
+	// for every record it creates (and reports 60 seconds back on read),
+	// so providers that need a round-trip-faithful libdns.Record.TTL
+	// should opt into this for both reads and writes.
+	UseZoneFile bool `json:"use_zone_file,omitempty"`
+
+	// HTTPClient, if set, is used instead of the default client for all
+	// requests, e.g. to configure a proxy, custom TLS, or a test double.
+	HTTPClient *http.Client `json:"-"`
+
+	// RateLimit caps outgoing requests to this many requests/sec. Zero (the
+	// default) leaves requests unlimited.
+	RateLimit float64 `json:"rate_limit,omitempty"`
+
+	// ZoneCacheTTL controls how long a zone name's resolved ID is cached,
+	// sparing callers like ACME clients that issue many requests against
+	// the same zone a repeated lookup. A nil value (the default) caches
+	// for 5 minutes; point it at a zero duration to disable caching.
+	ZoneCacheTTL *time.Duration `json:"-"`
+
+	once      sync.Once
+	client    *internal.Client
+	zoneCache sync.Map // zone name (no trailing dot) -> cachedZone
+}
+
+func (p *Provider) getClient() *internal.Client {
+	p.once.Do(func() {
+		p.client = internal.NewClient(p.Token)
+		if p.HTTPClient != nil {
+			p.client.HTTPClient = p.HTTPClient
+		}
+		if p.RateLimit > 0 {
+			p.client.SetRateLimit(p.RateLimit)
+		}
+	})
+	return p.client
+}
+
+// ListZones lists the zones accessible with the provider's token.
+func (p *Provider) ListZones(ctx context.Context) ([]libdns.Zone, error) {
+	zones, err := p.getClient().ListZones(ctx)
+	if err != nil {
+		return nil, err
+	}
+	libdnsZones := make([]libdns.Zone, 0, len(zones))
+	for _, z := range zones {
+		libdnsZones = append(libdnsZones, libdns.Zone{Name: z.Name + "."})
+	}
+	return libdnsZones, nil
+}
+
+// GetRecords lists all the records in the zone.
+func (p *Provider) GetRecords(ctx context.Context, zone string) ([]libdns.Record, error) {
+	if p.UseZoneFile {
+		return p.getRecordsViaZoneFile(ctx, zone)
+	}
+
+	c := p.getClient()
+	var recs []internal.Record
+	err := p.withZoneID(ctx, zone, func(zoneID int64) (err error) {
+		recs, err = c.ListRecords(ctx, zoneID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	libdnsRecords := make([]libdns.Record, 0, len(recs))
+	for _, r := range recs {
+		libdnsRecords = append(libdnsRecords, toLibdnsRecord(&r))
+	}
+	return libdnsRecords, nil
+}
+
+// AppendRecords adds records to the zone. It returns the records that were added.
+func (p *Provider) AppendRecords(ctx context.Context, zone string, records []libdns.Record) ([]libdns.Record, error) {
+	if p.UseZoneFile {
+		return p.appendRecordsViaZoneFile(ctx, zone, records)
+	}
+
+	c := p.getClient()
+	appended := make([]libdns.Record, 0, len(records))
+	for _, r := range records {
+		rec, err := fromLibdnsRecord(zone, &r)
+		if err != nil {
+			return nil, err
+		}
+		var added *internal.Record
+		err = p.withZoneID(ctx, zone, func(zoneID int64) (err error) {
+			added, err = c.CreateRecord(ctx, zoneID, rec)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		appended = append(appended, toLibdnsRecord(added))
+	}
+	return appended, nil
+}
+
+// SetRecords sets the records in the zone, either by updating existing records or creating new ones.
+// It returns the records that were set.
+func (p *Provider) SetRecords(ctx context.Context, zone string, records []libdns.Record) ([]libdns.Record, error) {
+	if p.UseZoneFile {
+		return p.setRecordsViaZoneFile(ctx, zone, records)
+	}
+
+	c := p.getClient()
+	var existing []internal.Record
+	err := p.withZoneID(ctx, zone, func(zoneID int64) (err error) {
+		existing, err = c.ListRecords(ctx, zoneID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	set := make([]libdns.Record, 0, len(records))
+	for _, r := range records {
+		rec, err := fromLibdnsRecord(zone, &r)
+		if err != nil {
+			return nil, err
+		}
+		if found := findRecord(existing, &r); found != nil {
+			rec.ID = found.ID
+			var updated *internal.Record
+			err = p.withZoneID(ctx, zone, func(zoneID int64) (err error) {
+				updated, err = c.UpdateRecord(ctx, zoneID, rec)
+				return err
+			})
+			if err != nil {
+				return nil, err
+			}
+			set = append(set, toLibdnsRecord(updated))
+			continue
+		}
+		var added *internal.Record
+		err = p.withZoneID(ctx, zone, func(zoneID int64) (err error) {
+			added, err = c.CreateRecord(ctx, zoneID, rec)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		set = append(set, toLibdnsRecord(added))
+	}
+	return set, nil
+}
+
+// DeleteRecords deletes the records from the zone. It returns the records that were deleted.
+func (p *Provider) DeleteRecords(ctx context.Context, zone string, records []libdns.Record) ([]libdns.Record, error) {
+	if p.UseZoneFile {
+		return p.deleteRecordsViaZoneFile(ctx, zone, records)
+	}
+
+	c := p.getClient()
+	var existing []internal.Record
+	err := p.withZoneID(ctx, zone, func(zoneID int64) (err error) {
+		existing, err = c.ListRecords(ctx, zoneID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	deleted := make([]libdns.Record, 0, len(records))
+	for _, r := range records {
+		found, err := findRecordWithValue(zone, existing, &r)
+		if err != nil {
+			return nil, err
+		}
+		if found == nil {
+			return nil, fmt.Errorf("record not found: %s %s %s", r.Type, r.Name, r.Value)
+		}
+		err = p.withZoneID(ctx, zone, func(zoneID int64) error {
+			return c.DeleteRecord(ctx, zoneID, found.ID)
+		})
+		if err != nil {
+			return nil, err
+		}
+		deleted = append(deleted, toLibdnsRecord(found))
+	}
+	return deleted, nil
+}
+
+// Interface guards
+var (
+	_ libdns.ZoneLister     = (*Provider)(nil)
+	_ libdns.RecordGetter   = (*Provider)(nil)
+	_ libdns.RecordAppender = (*Provider)(nil)
+	_ libdns.RecordSetter   = (*Provider)(nil)
+	_ libdns.RecordDeleter  = (*Provider)(nil)
+)
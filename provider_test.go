@@ -0,0 +1,49 @@
+package dynv6
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/libdns/dynv6/internal"
+	"github.com/libdns/libdns"
+)
+
+func TestDeleteRecordsDistinguishesSameNameAndTargetByRtypeFields(t *testing.T) {
+	records := []internal.Record{
+		{ID: 10, Type: "CAA", Name: "@", Flags: 0, Tag: "issue", Data: "letsencrypt.org"},
+		{ID: 11, Type: "CAA", Name: "@", Flags: 0, Tag: "issuewild", Data: "letsencrypt.org"},
+	}
+
+	var deletedID int64
+	p := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/zones/by-name/example.com":
+			fmt.Fprint(w, `{"id":1,"name":"example.com"}`)
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/records"):
+			json.NewEncoder(w).Encode(records)
+		case r.Method == http.MethodDelete:
+			parts := strings.Split(r.URL.Path, "/")
+			fmt.Sscanf(parts[len(parts)-1], "%d", &deletedID)
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	deleted, err := p.DeleteRecords(context.Background(), "example.com.", []libdns.Record{
+		{Type: "CAA", Name: "@", Value: `0 issuewild "letsencrypt.org"`},
+	})
+	if err != nil {
+		t.Fatalf("DeleteRecords() error = %v", err)
+	}
+	if len(deleted) != 1 {
+		t.Fatalf("DeleteRecords() returned %d records, want 1", len(deleted))
+	}
+	if deletedID != 11 {
+		t.Errorf("deleted record ID = %d, want 11 (issuewild), not 10 (issue)", deletedID)
+	}
+}
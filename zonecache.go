@@ -0,0 +1,84 @@
+package dynv6
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/libdns/dynv6/internal"
+)
+
+// defaultZoneCacheTTL is used when Provider.ZoneCacheTTL is nil.
+const defaultZoneCacheTTL = 5 * time.Minute
+
+type cachedZone struct {
+	id        int64
+	expiresAt time.Time
+}
+
+func (p *Provider) zoneCacheTTL() time.Duration {
+	if p.ZoneCacheTTL == nil {
+		return defaultZoneCacheTTL
+	}
+	return *p.ZoneCacheTTL
+}
+
+// resolveZoneID returns the numeric zone ID for zoneName, consulting the
+// cache before falling back to the API.
+func (p *Provider) resolveZoneID(ctx context.Context, zoneName string) (int64, error) {
+	key := strings.TrimSuffix(zoneName, ".")
+	ttl := p.zoneCacheTTL()
+
+	if ttl > 0 {
+		if v, ok := p.zoneCache.Load(key); ok {
+			cz := v.(cachedZone)
+			if time.Now().Before(cz.expiresAt) {
+				return cz.id, nil
+			}
+			p.zoneCache.Delete(key)
+		}
+	}
+
+	z, err := p.getClient().GetZoneByName(ctx, zoneName)
+	if err != nil {
+		return 0, err
+	}
+	if ttl > 0 {
+		p.zoneCache.Store(key, cachedZone{id: z.ID, expiresAt: time.Now().Add(ttl)})
+	}
+	return z.ID, nil
+}
+
+func (p *Provider) invalidateZoneCache(zoneName string) {
+	p.zoneCache.Delete(strings.TrimSuffix(zoneName, "."))
+}
+
+// withZoneID resolves zoneName to a zone ID and calls fn. If fn fails
+// because the zone no longer exists at the cached ID (a stale cache entry),
+// the cache is invalidated and fn is retried once against a freshly
+// resolved zone ID.
+func (p *Provider) withZoneID(ctx context.Context, zoneName string, fn func(zoneID int64) error) error {
+	zoneID, err := p.resolveZoneID(ctx, zoneName)
+	if err != nil {
+		return err
+	}
+
+	err = fn(zoneID)
+	if !isNotFound(err) {
+		return err
+	}
+
+	p.invalidateZoneCache(zoneName)
+	zoneID, err = p.resolveZoneID(ctx, zoneName)
+	if err != nil {
+		return err
+	}
+	return fn(zoneID)
+}
+
+func isNotFound(err error) bool {
+	var statusErr *internal.StatusError
+	return errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound
+}
@@ -0,0 +1,100 @@
+package dynv6
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/libdns/dynv6/internal"
+)
+
+func newTestProvider(t *testing.T, handler http.HandlerFunc) *Provider {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	p := &Provider{Token: "test"}
+	c := p.getClient()
+	baseURL, err := url.Parse(server.URL + "/")
+	if err != nil {
+		t.Fatalf("parsing test server URL: %v", err)
+	}
+	c.BaseURL = baseURL
+	return p
+}
+
+func TestResolveZoneIDCachesByDefault(t *testing.T) {
+	var calls int32
+	p := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		json.NewEncoder(w).Encode(internal.Zone{ID: 42, Name: "example.com"})
+	})
+
+	for i := 0; i < 3; i++ {
+		id, err := p.resolveZoneID(context.Background(), "example.com.")
+		if err != nil {
+			t.Fatalf("resolveZoneID() error = %v", err)
+		}
+		if id != 42 {
+			t.Errorf("resolveZoneID() = %d, want 42", id)
+		}
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("expected 1 API call after caching, got %d", got)
+	}
+}
+
+func TestResolveZoneIDCacheDisabled(t *testing.T) {
+	var calls int32
+	p := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		json.NewEncoder(w).Encode(internal.Zone{ID: 42, Name: "example.com"})
+	})
+	zero := time.Duration(0)
+	p.ZoneCacheTTL = &zero
+
+	for i := 0; i < 3; i++ {
+		if _, err := p.resolveZoneID(context.Background(), "example.com."); err != nil {
+			t.Fatalf("resolveZoneID() error = %v", err)
+		}
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Errorf("expected 3 API calls with caching disabled, got %d", got)
+	}
+}
+
+func TestWithZoneIDInvalidatesOnNotFound(t *testing.T) {
+	var zoneLookups, recordCalls int32
+	p := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/zones/by-name/example.com":
+			atomic.AddInt32(&zoneLookups, 1)
+			json.NewEncoder(w).Encode(internal.Zone{ID: 42, Name: "example.com"})
+		default:
+			n := atomic.AddInt32(&recordCalls, 1)
+			if n == 1 {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}
+	})
+
+	err := p.withZoneID(context.Background(), "example.com.", func(zoneID int64) error {
+		_, err := p.getClient().ListRecords(context.Background(), zoneID)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("withZoneID() error = %v", err)
+	}
+	if got := atomic.LoadInt32(&zoneLookups); got != 2 {
+		t.Errorf("expected zone to be re-resolved once after a 404, got %d lookups", got)
+	}
+}
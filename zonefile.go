@@ -0,0 +1,209 @@
+package dynv6
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/libdns/libdns"
+	"github.com/miekg/dns"
+)
+
+// zoneFileName returns the fully-qualified, dot-terminated record name used
+// in zone file presentation format.
+func zoneFileName(zone, name string) string {
+	name = strings.TrimSuffix(name, ".")
+	zone = strings.TrimSuffix(zone, ".")
+	if name == "" || name == "@" {
+		return zone + "."
+	}
+	return name + "." + zone + "."
+}
+
+// rrKey identifies a resource record by name and type, ignoring data/TTL.
+func rrKey(rr dns.RR) string {
+	h := rr.Header()
+	return strings.ToLower(strings.TrimSuffix(h.Name, ".")) + " " + dns.TypeToString[h.Rrtype]
+}
+
+// rrKeyWithData additionally distinguishes records by their rdata, mirroring
+// findRecordWithValue's REST-API equivalent.
+func rrKeyWithData(rr dns.RR) string {
+	h := rr.Header()
+	return rrKey(rr) + " " + strings.TrimSpace(strings.TrimPrefix(rr.String(), h.String()))
+}
+
+// libdnsRecordToRR converts a libdns.Record into a dns.RR, preserving its TTL.
+func libdnsRecordToRR(zone string, r *libdns.Record) (dns.RR, error) {
+	ttl := int64(r.TTL / time.Second)
+	if ttl <= 0 {
+		ttl = 60
+	}
+	line := fmt.Sprintf("%s %d IN %s %s", zoneFileName(zone, r.Name), ttl, r.Type, r.Value)
+	rr, err := dns.NewRR(line)
+	if err != nil {
+		return nil, fmt.Errorf("converting %s record %q to zone file syntax: %w", r.Type, r.Name, err)
+	}
+	return rr, nil
+}
+
+// rrToLibdnsRecord converts a dns.RR parsed from a zone file back into a libdns.Record.
+func rrToLibdnsRecord(zone string, rr dns.RR) libdns.Record {
+	h := rr.Header()
+	name := strings.TrimSuffix(h.Name, ".")
+	name = strings.TrimSuffix(name, strings.TrimSuffix(zone, "."))
+	name = strings.TrimSuffix(name, ".")
+	if name == "" {
+		name = "@"
+	}
+	value := strings.TrimSpace(strings.TrimPrefix(rr.String(), h.String()))
+	return libdns.Record{
+		Type:  dns.TypeToString[h.Rrtype],
+		Name:  name,
+		Value: value,
+		TTL:   time.Duration(h.Ttl) * time.Second,
+	}
+}
+
+// parseZoneFile parses RFC 1035 zone file syntax relative to origin.
+func parseZoneFile(zoneFile, origin string) ([]dns.RR, error) {
+	zp := dns.NewZoneParser(strings.NewReader(zoneFile), dns.Fqdn(origin), "")
+	var rrs []dns.RR
+	for rr, ok := zp.Next(); ok; rr, ok = zp.Next() {
+		rrs = append(rrs, rr)
+	}
+	if err := zp.Err(); err != nil {
+		return nil, fmt.Errorf("parsing zone file: %w", err)
+	}
+	return rrs, nil
+}
+
+// renderZoneFile writes rrs back out in zone file presentation form.
+func renderZoneFile(rrs []dns.RR) string {
+	var buf bytes.Buffer
+	for _, rr := range rrs {
+		buf.WriteString(rr.String())
+		buf.WriteByte('\n')
+	}
+	return buf.String()
+}
+
+func (p *Provider) loadZoneFile(ctx context.Context, zoneName string) (int64, []dns.RR, error) {
+	c := p.getClient()
+	var zoneID int64
+	var zf string
+	err := p.withZoneID(ctx, zoneName, func(id int64) (err error) {
+		zoneID = id
+		zf, err = c.GetZoneFile(ctx, id)
+		return err
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	rrs, err := parseZoneFile(zf, zoneName)
+	if err != nil {
+		return 0, nil, err
+	}
+	return zoneID, rrs, nil
+}
+
+func (p *Provider) getRecordsViaZoneFile(ctx context.Context, zoneName string) ([]libdns.Record, error) {
+	_, rrs, err := p.loadZoneFile(ctx, zoneName)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]libdns.Record, 0, len(rrs))
+	for _, rr := range rrs {
+		records = append(records, rrToLibdnsRecord(zoneName, rr))
+	}
+	return records, nil
+}
+
+func (p *Provider) appendRecordsViaZoneFile(ctx context.Context, zoneName string, records []libdns.Record) ([]libdns.Record, error) {
+	zoneID, rrs, err := p.loadZoneFile(ctx, zoneName)
+	if err != nil {
+		return nil, err
+	}
+
+	appended := make([]libdns.Record, 0, len(records))
+	for _, r := range records {
+		rr, err := libdnsRecordToRR(zoneName, &r)
+		if err != nil {
+			return nil, err
+		}
+		rrs = append(rrs, rr)
+		appended = append(appended, r)
+	}
+
+	if err := p.getClient().PutZoneFile(ctx, zoneID, renderZoneFile(rrs)); err != nil {
+		return nil, err
+	}
+	return appended, nil
+}
+
+func (p *Provider) setRecordsViaZoneFile(ctx context.Context, zoneName string, records []libdns.Record) ([]libdns.Record, error) {
+	zoneID, rrs, err := p.loadZoneFile(ctx, zoneName)
+	if err != nil {
+		return nil, err
+	}
+
+	set := make([]libdns.Record, 0, len(records))
+	for _, r := range records {
+		rr, err := libdnsRecordToRR(zoneName, &r)
+		if err != nil {
+			return nil, err
+		}
+		key := rrKey(rr)
+		replaced := false
+		for i, existing := range rrs {
+			if rrKey(existing) == key {
+				rrs[i] = rr
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			rrs = append(rrs, rr)
+		}
+		set = append(set, r)
+	}
+
+	if err := p.getClient().PutZoneFile(ctx, zoneID, renderZoneFile(rrs)); err != nil {
+		return nil, err
+	}
+	return set, nil
+}
+
+func (p *Provider) deleteRecordsViaZoneFile(ctx context.Context, zoneName string, records []libdns.Record) ([]libdns.Record, error) {
+	zoneID, rrs, err := p.loadZoneFile(ctx, zoneName)
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[string]bool, len(records))
+	for _, r := range records {
+		rr, err := libdnsRecordToRR(zoneName, &r)
+		if err != nil {
+			return nil, err
+		}
+		wanted[rrKeyWithData(rr)] = true
+	}
+
+	var deleted []libdns.Record
+	remaining := rrs[:0]
+	for _, existing := range rrs {
+		if wanted[rrKeyWithData(existing)] {
+			deleted = append(deleted, rrToLibdnsRecord(zoneName, existing))
+			continue
+		}
+		remaining = append(remaining, existing)
+	}
+
+	if err := p.getClient().PutZoneFile(ctx, zoneID, renderZoneFile(remaining)); err != nil {
+		return nil, err
+	}
+	return deleted, nil
+}
@@ -0,0 +1,141 @@
+package dynv6
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/libdns/libdns"
+)
+
+func TestZoneFileName(t *testing.T) {
+	tests := []struct {
+		name, zone, recordName, want string
+	}{
+		{"apex @", "example.com.", "@", "example.com."},
+		{"apex empty", "example.com.", "", "example.com."},
+		{"subdomain", "example.com.", "www", "www.example.com."},
+		{"zone without trailing dot", "example.com", "www", "www.example.com."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := zoneFileName(tt.zone, tt.recordName); got != tt.want {
+				t.Errorf("zoneFileName(%q, %q) = %q, want %q", tt.zone, tt.recordName, got, tt.want)
+			}
+		})
+	}
+}
+
+// zoneFileServer serves zoneFile for GETs of the zone-file endpoint, records
+// the last body/Content-Type it received for a PUT, and responds 200 to it.
+type zoneFileServer struct {
+	zoneFile     string
+	lastPutBody  string
+	lastPutCType string
+}
+
+func (s *zoneFileServer) handler(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == "/zones/by-name/example.com":
+		fmt.Fprintf(w, `{"id":1,"name":"example.com"}`)
+	case strings.HasSuffix(r.URL.Path, "/zonefile") && r.Method == http.MethodGet:
+		w.Header().Set("Content-Type", "text/dns")
+		io.WriteString(w, s.zoneFile)
+	case strings.HasSuffix(r.URL.Path, "/zonefile") && r.Method == http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		s.lastPutBody = string(body)
+		s.lastPutCType = r.Header.Get("Content-Type")
+		w.WriteHeader(http.StatusOK)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestAppendRecordsViaZoneFile(t *testing.T) {
+	srv := &zoneFileServer{zoneFile: "www 60 IN A 192.0.2.1\n"}
+	p := newTestProvider(t, srv.handler)
+	p.UseZoneFile = true
+
+	appended, err := p.AppendRecords(context.Background(), "example.com.", []libdns.Record{
+		{Type: "A", Name: "api", Value: "192.0.2.2", TTL: 300 * time.Second},
+	})
+	if err != nil {
+		t.Fatalf("AppendRecords() error = %v", err)
+	}
+	if len(appended) != 1 || appended[0].Name != "api" {
+		t.Fatalf("AppendRecords() = %+v, want one record named api", appended)
+	}
+
+	if srv.lastPutCType != "text/dns" {
+		t.Errorf("PUT Content-Type = %q, want text/dns", srv.lastPutCType)
+	}
+	if !strings.Contains(srv.lastPutBody, "www") || !strings.Contains(srv.lastPutBody, "api") {
+		t.Errorf("uploaded zone file missing expected records:\n%s", srv.lastPutBody)
+	}
+}
+
+func TestGetRecordsViaZoneFilePreservesCustomTTL(t *testing.T) {
+	srv := &zoneFileServer{zoneFile: "www 300 IN A 192.0.2.1\n"}
+	p := newTestProvider(t, srv.handler)
+	p.UseZoneFile = true
+
+	records, err := p.GetRecords(context.Background(), "example.com.")
+	if err != nil {
+		t.Fatalf("GetRecords() error = %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("GetRecords() = %+v, want one record", records)
+	}
+	if records[0].TTL != 300*time.Second {
+		t.Errorf("GetRecords()[0].TTL = %v, want 300s (not the REST API's hardcoded 60s)", records[0].TTL)
+	}
+}
+
+func TestSetRecordsViaZoneFileReplacesByKey(t *testing.T) {
+	srv := &zoneFileServer{zoneFile: "www 60 IN A 192.0.2.1\n"}
+	p := newTestProvider(t, srv.handler)
+	p.UseZoneFile = true
+
+	_, err := p.SetRecords(context.Background(), "example.com.", []libdns.Record{
+		{Type: "A", Name: "www", Value: "192.0.2.9", TTL: 120 * time.Second},
+	})
+	if err != nil {
+		t.Fatalf("SetRecords() error = %v", err)
+	}
+
+	if strings.Count(srv.lastPutBody, "www") != 1 {
+		t.Fatalf("expected www record to be replaced, not duplicated:\n%s", srv.lastPutBody)
+	}
+	if !strings.Contains(srv.lastPutBody, "192.0.2.9") {
+		t.Errorf("uploaded zone file missing updated value:\n%s", srv.lastPutBody)
+	}
+	if strings.Contains(srv.lastPutBody, "192.0.2.1") {
+		t.Errorf("uploaded zone file still has stale value:\n%s", srv.lastPutBody)
+	}
+}
+
+func TestDeleteRecordsViaZoneFile(t *testing.T) {
+	srv := &zoneFileServer{zoneFile: "www 60 IN A 192.0.2.1\napi 60 IN A 192.0.2.2\n"}
+	p := newTestProvider(t, srv.handler)
+	p.UseZoneFile = true
+
+	deleted, err := p.DeleteRecords(context.Background(), "example.com.", []libdns.Record{
+		{Type: "A", Name: "www", Value: "192.0.2.1"},
+	})
+	if err != nil {
+		t.Fatalf("DeleteRecords() error = %v", err)
+	}
+	if len(deleted) != 1 || deleted[0].Name != "www" {
+		t.Fatalf("DeleteRecords() = %+v, want one record named www", deleted)
+	}
+	if strings.Contains(srv.lastPutBody, "www") {
+		t.Errorf("uploaded zone file should no longer contain www:\n%s", srv.lastPutBody)
+	}
+	if !strings.Contains(srv.lastPutBody, "api") {
+		t.Errorf("uploaded zone file should still contain api:\n%s", srv.lastPutBody)
+	}
+}